@@ -1,16 +1,11 @@
 package business
 
 import (
-	"bytes"
 	"context"
-	"encoding/hex"
 	"errors"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/stretchr/testify/mock"
 	"log"
@@ -20,8 +15,11 @@ import (
 )
 
 type AuctionStatus struct {
-	Ended      bool  `json:"ended"`
-	HighestBid int64 `json:"highestBid"`
+	Ended      bool   `json:"ended"`
+	HighestBid int64  `json:"highestBid"`
+	BiddingEnd int64  `json:"biddingEnd,omitempty"`
+	RevealEnd  int64  `json:"revealEnd,omitempty"`
+	Phase      string `json:"phase,omitempty"`
 }
 
 type Stats struct {
@@ -30,12 +28,16 @@ type Stats struct {
 }
 
 type Bid struct {
-	Sender string
-	Amount int64
+	Sender    string
+	SenderEns string `json:"senderEns,omitempty"`
+	Amount    int64
 }
 
 type EthConnection struct {
-	client *ethclient.Client
+	chains         *chainClients
+	primaryChainID int64
+	ensCache       *ensCache
+	signer         *SignerManager
 }
 
 type EthConnectionMock struct {
@@ -46,11 +48,19 @@ type HigherBidAlreadySubmitted struct{}
 type AuctionEnded struct{}
 
 type Connection interface {
-	GetAuctionStatus() (AuctionStatus, error)
-	ListAllBids() ([]Bid, error)
-	Bid(amount int64) error
-	Stats() (Stats, error)
-	Deploy(durationInSeconds int64, beneficiaryAddress string) (string, string, error)
+	GetAuctionStatus(contractAddr common.Address, chainID int64) (AuctionStatus, error)
+	ListAllBids(contractAddr common.Address, chainID int64) ([]Bid, error)
+	Bid(contractAddr common.Address, chainID int64, amount int64) error
+	Stats(contractAddr common.Address, chainID int64) (Stats, error)
+	Deploy(chainID int64, durationInSeconds int64, beneficiaryAddress string) (string, string, error)
+	DeployBlindAuction(chainID int64, biddingTimeInSeconds int64, revealTimeInSeconds int64, beneficiaryAddress string) (string, string, error)
+	NewAuctionEvents(ctx context.Context, contractAddr common.Address, chainID int64) (<-chan Bid, <-chan error)
+	CommitBid(contractAddr common.Address, chainID int64, blindedHash [32]byte, deposit int64) error
+	RevealBids(contractAddr common.Address, chainID int64, values []int64, fakes []bool, secrets [][32]byte) error
+	Withdraw(contractAddr common.Address, chainID int64) error
+	BlindAuctionStatus(contractAddr common.Address, chainID int64) (AuctionStatus, error)
+	ResolveName(name string) (common.Address, error)
+	ReverseResolve(addr common.Address) (string, error)
 }
 
 func (err HigherBidAlreadySubmitted) Error() string {
@@ -75,23 +85,44 @@ func NewBlockchainConnection() (*EthConnection, error) {
 		return nil, err
 	}
 
+	primaryChainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	chains, err := newChainClients(client, primaryChainID.Int64())
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := NewSignerManager()
+	if err != nil {
+		return nil, err
+	}
+
 	return &EthConnection{
-		client: client,
+		chains:         chains,
+		primaryChainID: primaryChainID.Int64(),
+		ensCache:       newEnsCache(),
+		signer:         signer,
 	}, nil
 }
 
-func (ec *EthConnection) GetAuctionStatus() (AuctionStatus, error) {
+func (ec *EthConnection) primaryClient() (*ethclient.Client, error) {
 
-	actionStatus := AuctionStatus{}
+	return ec.chains.get(ec.primaryChainID)
+}
 
-	contractAddr, found := os.LookupEnv("CONTRACT_DEPLOYMENT_ADDR")
-	if !found {
-		return AuctionStatus{}, errors.New("Instance url is needed to connect to an ethereum node")
+func (ec *EthConnection) GetAuctionStatus(contractAddr common.Address, chainID int64) (AuctionStatus, error) {
+
+	client, err := ec.chains.get(chainID)
+	if err != nil {
+		return AuctionStatus{}, err
 	}
 
-	addr := common.HexToAddress(contractAddr)
+	actionStatus := AuctionStatus{}
 
-	acn, err := NewSimpleAuction(addr, ec.client)
+	acn, err := NewSimpleAuction(contractAddr, client)
 	if err != nil {
 		return AuctionStatus{}, err
 	}
@@ -101,7 +132,7 @@ func (ec *EthConnection) GetAuctionStatus() (AuctionStatus, error) {
 		return AuctionStatus{}, err
 	}
 
-	header, err := ec.client.HeaderByNumber(context.Background(), nil)
+	header, err := client.HeaderByNumber(context.Background(), nil)
 	if err != nil {
 		return AuctionStatus{}, err
 	}
@@ -118,22 +149,20 @@ func (ec *EthConnection) GetAuctionStatus() (AuctionStatus, error) {
 	return actionStatus, nil
 }
 
-func (ec *EthConnection) ListAllBids() ([]Bid, error) {
+func (ec *EthConnection) ListAllBids(contractAddr common.Address, chainID int64) ([]Bid, error) {
 
-	contractAddr, found := os.LookupEnv("CONTRACT_DEPLOYMENT_ADDR")
-	if !found {
-		return nil, errors.New("contract address is needed to connect to the auction")
+	client, err := ec.chains.get(chainID)
+	if err != nil {
+		return nil, err
 	}
 
-	addr := common.HexToAddress(contractAddr)
-
 	query := ethereum.FilterQuery{
 		Addresses: []common.Address{
-			addr,
+			contractAddr,
 		},
 	}
 
-	logs, err := ec.client.FilterLogs(context.Background(), query)
+	logs, err := client.FilterLogs(context.Background(), query)
 	if err != nil {
 		return nil, err
 	}
@@ -152,78 +181,38 @@ func (ec *EthConnection) ListAllBids() ([]Bid, error) {
 			return nil, err
 		}
 
+		sender := event[0].(common.Address)
+
 		bid := Bid{
-			Sender: event[0].(common.Address).String(),
+			Sender: sender.String(),
 			Amount: event[1].(*big.Int).Int64(),
 		}
 
+		if senderEns, err := ec.ReverseResolve(sender); err == nil && senderEns != sender.String() {
+			bid.SenderEns = senderEns
+		}
+
 		bids = append(bids, bid)
 	}
 
 	return bids, nil
 }
 
-func (ec *EthConnection) Bid(amount int64) error {
-
-	contractAddr, found := os.LookupEnv("CONTRACT_DEPLOYMENT_ADDR")
-	if !found {
-		return errors.New("contract address is needed to connect to the auction")
-	}
+func (ec *EthConnection) Bid(contractAddr common.Address, chainID int64, amount int64) error {
 
-	addr := common.HexToAddress(contractAddr)
-
-	acn, err := NewSimpleAuction(addr, ec.client)
-	if err != nil {
-		return err
-	}
-
-	bidderKey, found := os.LookupEnv("BIDDER_ACC_PRIVATE_KEY")
-	if !found {
-		return errors.New("bidder private is required to submit a bid to the auction")
-	}
-
-	ks := keystore.NewKeyStore("./keys", keystore.StandardScryptN, keystore.StandardScryptP)
-
-	privateKeyBytes, err := hex.DecodeString(bidderKey)
-	if err != nil {
-		log.Println(err, "----1")
-		return err
-	}
-
-	privateKey, err := crypto.ToECDSA(privateKeyBytes)
-	if err != nil {
-		log.Println(err, "----2")
-		return err
-	}
-
-	// no need to add passphrases since the key is deleted right away
-	acc, err := ks.ImportECDSA(privateKey, "")
-	if err != nil {
-		log.Println(err, "----3")
-		return err
-	}
-
-	key, err := ks.Export(acc, "", "")
+	client, err := ec.chains.get(chainID)
 	if err != nil {
-		log.Println(err, "----4")
 		return err
 	}
 
-	err = ks.Delete(acc, "")
+	acn, err := NewSimpleAuction(contractAddr, client)
 	if err != nil {
-		log.Println(err, "----5")
 		return err
 	}
 
-	chainId, err := ec.client.ChainID(context.Background())
+	auth, err := ec.signer.Transactor(context.Background(), client, chainID, SignerBidder)
 	if err != nil {
-		log.Println(err, "----6")
-		return err
-	}
-
-	auth, err := bind.NewTransactorWithChainID(bytes.NewReader(key), "", chainId)
-	if err != nil {
-		log.Printf("Failed to create authorized transactor: %v", err)
+		log.Println(err)
 		return err
 	}
 
@@ -231,6 +220,7 @@ func (ec *EthConnection) Bid(amount int64) error {
 
 	_, err = acn.Bid(auth)
 	if err != nil {
+		ec.signer.ReleaseNonce(SignerBidder, chainID)
 
 		if strings.Contains(err.Error(), "There already is a higher bid") {
 			return HigherBidAlreadySubmitted{}
@@ -246,22 +236,20 @@ func (ec *EthConnection) Bid(amount int64) error {
 	return nil
 }
 
-func (ec *EthConnection) Stats() (Stats, error) {
+func (ec *EthConnection) Stats(contractAddr common.Address, chainID int64) (Stats, error) {
 
-	contractAddr, found := os.LookupEnv("CONTRACT_DEPLOYMENT_ADDR")
-	if !found {
-		return Stats{}, errors.New("contract address is needed to connect to the auction")
+	client, err := ec.chains.get(chainID)
+	if err != nil {
+		return Stats{}, err
 	}
 
-	addr := common.HexToAddress(contractAddr)
-
 	query := ethereum.FilterQuery{
 		Addresses: []common.Address{
-			addr,
+			contractAddr,
 		},
 	}
 
-	logs, err := ec.client.FilterLogs(context.Background(), query)
+	logs, err := client.FilterLogs(context.Background(), query)
 	if err != nil {
 		return Stats{}, err
 	}
@@ -287,61 +275,29 @@ func (ec *EthConnection) Stats() (Stats, error) {
 	return stats, nil
 }
 
-func (ec *EthConnection) Deploy(durationInSeconds int64, beneficiaryAddress string) (string, string, error) {
-
-	deployerKey, found := os.LookupEnv("DEPLOYER_ACC_PRIVATE_KEY")
-	if !found {
-		return "", "", errors.New("deployer private is required to deploy the auction contract")
-	}
-
-	ks := keystore.NewKeyStore("./keys", keystore.StandardScryptN, keystore.StandardScryptP)
+func (ec *EthConnection) Deploy(chainID int64, durationInSeconds int64, beneficiaryAddress string) (string, string, error) {
 
-	privateKeyBytes, err := hex.DecodeString(deployerKey)
+	client, err := ec.chains.get(chainID)
 	if err != nil {
-		log.Println(err)
 		return "", "", err
 	}
 
-	privateKey, err := crypto.ToECDSA(privateKeyBytes)
+	auth, err := ec.signer.Transactor(context.Background(), client, chainID, SignerDeployer)
 	if err != nil {
 		log.Println(err)
 		return "", "", err
 	}
 
-	acc, err := ks.ImportECDSA(privateKey, "")
+	beneficiary, err := ec.ResolveName(beneficiaryAddress)
 	if err != nil {
-		log.Println(err)
+		ec.signer.ReleaseNonce(SignerDeployer, chainID)
+		log.Printf("Failed to resolve beneficiary address: %v", err)
 		return "", "", err
 	}
 
-	key, err := ks.Export(acc, "", "")
-	if err != nil {
-		log.Println(err)
-		return "", "", err
-	}
-
-	err = ks.Delete(acc, "")
-	if err != nil {
-		log.Println(err)
-		return "", "", err
-	}
-
-	chainId, err := ec.client.ChainID(context.Background())
-	if err != nil {
-		log.Println(err)
-		return "", "", err
-	}
-
-	auth, err := bind.NewTransactorWithChainID(bytes.NewReader(key), "", chainId)
-	if err != nil {
-		log.Printf("Failed to create authorized transactor: %v", err)
-		return "", "", err
-	}
-
-	beneficiary := common.HexToAddress(beneficiaryAddress)
-
-	address, tx, _, err := DeploySimpleAuction(auth, ec.client, big.NewInt(durationInSeconds), beneficiary)
+	address, tx, _, err := DeploySimpleAuction(auth, client, big.NewInt(durationInSeconds), beneficiary)
 	if err != nil {
+		ec.signer.ReleaseNonce(SignerDeployer, chainID)
 		log.Printf("Failed to deploy new auction contract: %v", err)
 		return "", "", err
 	}
@@ -349,32 +305,80 @@ func (ec *EthConnection) Deploy(durationInSeconds int64, beneficiaryAddress stri
 	return address.String(), tx.Hash().String(), nil
 }
 
-func (m *EthConnectionMock) GetAuctionStatus() (AuctionStatus, error) {
+func (m *EthConnectionMock) GetAuctionStatus(contractAddr common.Address, chainID int64) (AuctionStatus, error) {
 
-	args := m.Called()
+	args := m.Called(contractAddr, chainID)
 	return args.Get(0).(AuctionStatus), args.Error(1)
 }
 
-func (m *EthConnectionMock) ListAllBids() ([]Bid, error) {
+func (m *EthConnectionMock) ListAllBids(contractAddr common.Address, chainID int64) ([]Bid, error) {
 
-	args := m.Called()
+	args := m.Called(contractAddr, chainID)
 	return args.Get(0).([]Bid), args.Error(1)
 }
 
-func (m *EthConnectionMock) Bid(amount int64) error {
+func (m *EthConnectionMock) Bid(contractAddr common.Address, chainID int64, amount int64) error {
 
-	args := m.Called(amount)
+	args := m.Called(contractAddr, chainID, amount)
 	return args.Error(0)
 }
 
-func (m *EthConnectionMock) Stats() (Stats, error) {
+func (m *EthConnectionMock) Stats(contractAddr common.Address, chainID int64) (Stats, error) {
 
-	args := m.Called()
+	args := m.Called(contractAddr, chainID)
 	return args.Get(0).(Stats), args.Error(1)
 }
 
-func (m *EthConnectionMock) Deploy(durationInSeconds int64, beneficiaryAddress string) (string, string, error) {
+func (m *EthConnectionMock) Deploy(chainID int64, durationInSeconds int64, beneficiaryAddress string) (string, string, error) {
 
-	args := m.Called(durationInSeconds, beneficiaryAddress)
+	args := m.Called(chainID, durationInSeconds, beneficiaryAddress)
 	return args.Get(0).(string), args.Get(1).(string), args.Error(2)
 }
+
+func (m *EthConnectionMock) DeployBlindAuction(chainID int64, biddingTimeInSeconds int64, revealTimeInSeconds int64, beneficiaryAddress string) (string, string, error) {
+
+	args := m.Called(chainID, biddingTimeInSeconds, revealTimeInSeconds, beneficiaryAddress)
+	return args.Get(0).(string), args.Get(1).(string), args.Error(2)
+}
+
+func (m *EthConnectionMock) NewAuctionEvents(ctx context.Context, contractAddr common.Address, chainID int64) (<-chan Bid, <-chan error) {
+
+	args := m.Called(ctx, contractAddr, chainID)
+	return args.Get(0).(<-chan Bid), args.Get(1).(<-chan error)
+}
+
+func (m *EthConnectionMock) CommitBid(contractAddr common.Address, chainID int64, blindedHash [32]byte, deposit int64) error {
+
+	args := m.Called(contractAddr, chainID, blindedHash, deposit)
+	return args.Error(0)
+}
+
+func (m *EthConnectionMock) RevealBids(contractAddr common.Address, chainID int64, values []int64, fakes []bool, secrets [][32]byte) error {
+
+	args := m.Called(contractAddr, chainID, values, fakes, secrets)
+	return args.Error(0)
+}
+
+func (m *EthConnectionMock) Withdraw(contractAddr common.Address, chainID int64) error {
+
+	args := m.Called(contractAddr, chainID)
+	return args.Error(0)
+}
+
+func (m *EthConnectionMock) BlindAuctionStatus(contractAddr common.Address, chainID int64) (AuctionStatus, error) {
+
+	args := m.Called(contractAddr, chainID)
+	return args.Get(0).(AuctionStatus), args.Error(1)
+}
+
+func (m *EthConnectionMock) ResolveName(name string) (common.Address, error) {
+
+	args := m.Called(name)
+	return args.Get(0).(common.Address), args.Error(1)
+}
+
+func (m *EthConnectionMock) ReverseResolve(addr common.Address) (string, error) {
+
+	args := m.Called(addr)
+	return args.Get(0).(string), args.Error(1)
+}
@@ -0,0 +1,207 @@
+package business
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"log"
+	"math/big"
+	"strings"
+)
+
+const (
+	phaseBidding = "bidding"
+	phaseReveal  = "reveal"
+	phaseEnded   = "ended"
+)
+
+// blindAuction resolves the BlindAuction contract at contractAddr on chainID.
+func (ec *EthConnection) blindAuction(contractAddr common.Address, chainID int64) (*BlindAuction, *ethclient.Client, error) {
+
+	client, err := ec.chains.get(chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acn, err := NewBlindAuction(contractAddr, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return acn, client, nil
+}
+
+// DeployBlindAuction deploys a new BlindAuction contract on chainID, with a
+// bidding phase lasting biddingTimeInSeconds followed by a reveal phase
+// lasting revealTimeInSeconds.
+func (ec *EthConnection) DeployBlindAuction(chainID int64, biddingTimeInSeconds int64, revealTimeInSeconds int64, beneficiaryAddress string) (string, string, error) {
+
+	client, err := ec.chains.get(chainID)
+	if err != nil {
+		return "", "", err
+	}
+
+	auth, err := ec.signer.Transactor(context.Background(), client, chainID, SignerDeployer)
+	if err != nil {
+		log.Println(err)
+		return "", "", err
+	}
+
+	beneficiary, err := ec.ResolveName(beneficiaryAddress)
+	if err != nil {
+		ec.signer.ReleaseNonce(SignerDeployer, chainID)
+		log.Printf("Failed to resolve beneficiary address: %v", err)
+		return "", "", err
+	}
+
+	address, tx, _, err := DeployBlindAuction(auth, client, big.NewInt(biddingTimeInSeconds), big.NewInt(revealTimeInSeconds), beneficiary)
+	if err != nil {
+		ec.signer.ReleaseNonce(SignerDeployer, chainID)
+		log.Printf("Failed to deploy new blind auction contract: %v", err)
+		return "", "", err
+	}
+
+	return address.String(), tx.Hash().String(), nil
+}
+
+// CommitBid submits the blinded hash of a bid along with a deposit, without
+// revealing the real bid amount until RevealBids is called.
+func (ec *EthConnection) CommitBid(contractAddr common.Address, chainID int64, blindedHash [32]byte, deposit int64) error {
+
+	acn, client, err := ec.blindAuction(contractAddr, chainID)
+	if err != nil {
+		return err
+	}
+
+	auth, err := ec.signer.Transactor(context.Background(), client, chainID, SignerBidder)
+	if err != nil {
+		return err
+	}
+
+	auth.Value = big.NewInt(deposit)
+
+	_, err = acn.Bid(auth, blindedHash)
+	if err != nil {
+		ec.signer.ReleaseNonce(SignerBidder, chainID)
+
+		if strings.Contains(err.Error(), "Auction already ended") {
+			return AuctionEnded{}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// RevealBids discloses the real values, fake flags and secrets behind every
+// blinded bid a bidder committed during the bidding phase.
+func (ec *EthConnection) RevealBids(contractAddr common.Address, chainID int64, values []int64, fakes []bool, secrets [][32]byte) error {
+
+	acn, client, err := ec.blindAuction(contractAddr, chainID)
+	if err != nil {
+		return err
+	}
+
+	auth, err := ec.signer.Transactor(context.Background(), client, chainID, SignerBidder)
+	if err != nil {
+		return err
+	}
+
+	bigValues := make([]*big.Int, len(values))
+	for i, value := range values {
+		bigValues[i] = big.NewInt(value)
+	}
+
+	_, err = acn.Reveal(auth, bigValues, fakes, secrets)
+	if err != nil {
+		ec.signer.ReleaseNonce(SignerBidder, chainID)
+		return err
+	}
+
+	return nil
+}
+
+// Withdraw pulls back any outbid deposits owed to the caller.
+func (ec *EthConnection) Withdraw(contractAddr common.Address, chainID int64) error {
+
+	acn, client, err := ec.blindAuction(contractAddr, chainID)
+	if err != nil {
+		return err
+	}
+
+	auth, err := ec.signer.Transactor(context.Background(), client, chainID, SignerBidder)
+	if err != nil {
+		return err
+	}
+
+	_, err = acn.Withdraw(auth)
+	if err != nil {
+		ec.signer.ReleaseNonce(SignerBidder, chainID)
+		return err
+	}
+
+	return nil
+}
+
+// BlindAuctionStatus reports the current phase of the sealed-bid auction
+// alongside the bidding/reveal deadlines.
+func (ec *EthConnection) BlindAuctionStatus(contractAddr common.Address, chainID int64) (AuctionStatus, error) {
+
+	acn, client, err := ec.blindAuction(contractAddr, chainID)
+	if err != nil {
+		return AuctionStatus{}, err
+	}
+
+	biddingEnd, err := acn.BiddingEnd(nil)
+	if err != nil {
+		return AuctionStatus{}, err
+	}
+
+	revealEnd, err := acn.RevealEnd(nil)
+	if err != nil {
+		return AuctionStatus{}, err
+	}
+
+	ended, err := acn.Ended(nil)
+	if err != nil {
+		return AuctionStatus{}, err
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return AuctionStatus{}, err
+	}
+
+	highestBid, err := acn.HighestBid(nil)
+	if err != nil {
+		return AuctionStatus{}, err
+	}
+
+	status := AuctionStatus{
+		Ended:      ended,
+		HighestBid: highestBid.Int64(),
+		BiddingEnd: biddingEnd.Int64(),
+		RevealEnd:  revealEnd.Int64(),
+		Phase:      blindAuctionPhase(header.Time, biddingEnd.Uint64(), revealEnd.Uint64(), ended),
+	}
+
+	return status, nil
+}
+
+func blindAuctionPhase(now, biddingEnd, revealEnd uint64, ended bool) string {
+
+	if ended {
+		return phaseEnded
+	}
+
+	if now < biddingEnd {
+		return phaseBidding
+	}
+
+	if now < revealEnd {
+		return phaseReveal
+	}
+
+	return phaseEnded
+}
@@ -0,0 +1,71 @@
+package business
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// chainClients resolves an ethclient.Client per chain ID so a single
+// deployment of this service can serve auctions on more than one network
+// (e.g. mainnet, Sepolia and a local devnet) at once. Additional endpoints
+// are configured via CHAIN_RPC_URLS as a comma-separated list of
+// "chainID=url" pairs, on top of the primary INSTANCE_URL connection.
+type chainClients struct {
+	clients map[int64]*ethclient.Client
+}
+
+func newChainClients(primary *ethclient.Client, primaryChainID int64) (*chainClients, error) {
+
+	cc := &chainClients{
+		clients: map[int64]*ethclient.Client{primaryChainID: primary},
+	}
+
+	rpcUrls, found := os.LookupEnv("CHAIN_RPC_URLS")
+	if !found {
+		return cc, nil
+	}
+
+	for _, pair := range strings.Split(rpcUrls, ",") {
+
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed CHAIN_RPC_URLS entry %q, expected chainID=url", pair)
+		}
+
+		chainID, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, exists := cc.clients[chainID]; exists {
+			continue
+		}
+
+		client, err := ethclient.Dial(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		cc.clients[chainID] = client
+	}
+
+	return cc, nil
+}
+
+func (cc *chainClients) get(chainID int64) (*ethclient.Client, error) {
+
+	client, found := cc.clients[chainID]
+	if !found {
+		return nil, fmt.Errorf("no rpc endpoint configured for chain %d", chainID)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,96 @@
+package business
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ens "github.com/wealdtech/go-ens/v3"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ensCacheTTL = 10 * time.Minute
+
+type ensCache struct {
+	mu      sync.Mutex
+	forward map[string]ensCacheEntry
+	reverse map[common.Address]ensCacheEntry
+}
+
+type ensCacheEntry struct {
+	address   common.Address
+	name      string
+	expiresAt time.Time
+}
+
+func newEnsCache() *ensCache {
+
+	return &ensCache{
+		forward: make(map[string]ensCacheEntry),
+		reverse: make(map[common.Address]ensCacheEntry),
+	}
+}
+
+// ResolveName resolves an ENS name (e.g. alice.eth) to its registered
+// address. Names that are already hex addresses are returned unchanged, so
+// callers can accept either interchangeably.
+func (ec *EthConnection) ResolveName(name string) (common.Address, error) {
+
+	if !strings.HasSuffix(name, ".eth") {
+		return common.HexToAddress(name), nil
+	}
+
+	ec.ensCache.mu.Lock()
+	entry, found := ec.ensCache.forward[name]
+	ec.ensCache.mu.Unlock()
+
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.address, nil
+	}
+
+	client, err := ec.primaryClient()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	addr, err := ens.Resolve(client, name)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	ec.ensCache.mu.Lock()
+	ec.ensCache.forward[name] = ensCacheEntry{address: addr, expiresAt: time.Now().Add(ensCacheTTL)}
+	ec.ensCache.mu.Unlock()
+
+	return addr, nil
+}
+
+// ReverseResolve looks up the primary ENS name registered for addr. On
+// chains without ENS deployed (e.g. a local Ganache instance), or when addr
+// has no reverse record, it degrades gracefully by returning the hex
+// address unchanged.
+func (ec *EthConnection) ReverseResolve(addr common.Address) (string, error) {
+
+	ec.ensCache.mu.Lock()
+	entry, found := ec.ensCache.reverse[addr]
+	ec.ensCache.mu.Unlock()
+
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.name, nil
+	}
+
+	client, err := ec.primaryClient()
+	if err != nil {
+		return addr.String(), nil
+	}
+
+	name, err := ens.ReverseResolve(client, addr)
+	if err != nil {
+		return addr.String(), nil
+	}
+
+	ec.ensCache.mu.Lock()
+	ec.ensCache.reverse[addr] = ensCacheEntry{name: name, expiresAt: time.Now().Add(ensCacheTTL)}
+	ec.ensCache.mu.Unlock()
+
+	return name, nil
+}
@@ -0,0 +1,226 @@
+package business
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const eventsPollInterval = 5 * time.Second
+const eventsReconnectBackoff = 2 * time.Second
+
+// NewAuctionEvents streams HighestBidIncreased events for contractAddr on
+// chainID as they are mined. On a WSS endpoint it subscribes via
+// SubscribeFilterLogs and reconnects with a fixed backoff if the node drops
+// the subscription; on an HTTP-only endpoint it falls back to polling
+// FilterLogs every eventsPollInterval.
+func (ec *EthConnection) NewAuctionEvents(ctx context.Context, contractAddr common.Address, chainID int64) (<-chan Bid, <-chan error) {
+
+	bids := make(chan Bid)
+	errs := make(chan error, 1)
+
+	client, err := ec.chains.get(chainID)
+	if err != nil {
+		errs <- err
+		close(bids)
+		close(errs)
+		return bids, errs
+	}
+
+	contractAbi, err := abi.JSON(strings.NewReader(SimpleAuctionMetaData.ABI))
+	if err != nil {
+		errs <- err
+		close(bids)
+		close(errs)
+		return bids, errs
+	}
+
+	if !supportsSubscriptions(ctx, client, contractAddr) {
+		go ec.pollAuctionEvents(ctx, client, contractAddr, contractAbi, bids, errs)
+		return bids, errs
+	}
+
+	go ec.subscribeAuctionEvents(ctx, client, contractAddr, contractAbi, bids, errs)
+
+	return bids, errs
+}
+
+// supportsSubscriptions probes whether client accepts log subscriptions;
+// HTTP-only RPC endpoints reject them outright, unlike WSS ones.
+func supportsSubscriptions(ctx context.Context, client *ethclient.Client, contractAddr common.Address) bool {
+
+	logsCh := make(chan types.Log)
+	query := ethereum.FilterQuery{Addresses: []common.Address{contractAddr}}
+
+	sub, err := client.SubscribeFilterLogs(ctx, query, logsCh)
+	if err != nil {
+		return false
+	}
+
+	sub.Unsubscribe()
+
+	return true
+}
+
+func (ec *EthConnection) subscribeAuctionEvents(ctx context.Context, client *ethclient.Client, addr common.Address, contractAbi abi.ABI, bids chan<- Bid, errs chan<- error) {
+
+	defer close(bids)
+	defer close(errs)
+
+	query := ethereum.FilterQuery{Addresses: []common.Address{addr}}
+
+	for {
+		logsCh := make(chan types.Log)
+
+		sub, err := client.SubscribeFilterLogs(ctx, query, logsCh)
+		if err != nil {
+			if !sendErr(ctx, errs, err) {
+				return
+			}
+
+			if !waitBeforeRetry(ctx, eventsReconnectBackoff) {
+				return
+			}
+
+			continue
+		}
+
+		if !ec.drainSubscription(ctx, sub, logsCh, contractAbi, bids, errs) {
+			return
+		}
+
+		if !waitBeforeRetry(ctx, eventsReconnectBackoff) {
+			return
+		}
+	}
+}
+
+func (ec *EthConnection) drainSubscription(ctx context.Context, sub ethereum.Subscription, logsCh chan types.Log, contractAbi abi.ABI, bids chan<- Bid, errs chan<- error) bool {
+
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case err := <-sub.Err():
+			return sendErr(ctx, errs, err)
+		case eventLog := <-logsCh:
+			bid, err := decodeBidLog(contractAbi, eventLog)
+			if err != nil {
+				if !sendErr(ctx, errs, err) {
+					return false
+				}
+
+				continue
+			}
+
+			if !sendBid(ctx, bids, bid) {
+				return false
+			}
+		}
+	}
+}
+
+func (ec *EthConnection) pollAuctionEvents(ctx context.Context, client *ethclient.Client, addr common.Address, contractAbi abi.ABI, bids chan<- Bid, errs chan<- error) {
+
+	defer close(bids)
+	defer close(errs)
+
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+
+	var lastBlock uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			query := ethereum.FilterQuery{Addresses: []common.Address{addr}}
+			if lastBlock != 0 {
+				query.FromBlock = new(big.Int).SetUint64(lastBlock + 1)
+			}
+
+			logs, err := client.FilterLogs(ctx, query)
+			if err != nil {
+				if !sendErr(ctx, errs, err) {
+					return
+				}
+				continue
+			}
+
+			for _, eventLog := range logs {
+				bid, err := decodeBidLog(contractAbi, eventLog)
+				if err != nil {
+					if !sendErr(ctx, errs, err) {
+						return
+					}
+					continue
+				}
+
+				if !sendBid(ctx, bids, bid) {
+					return
+				}
+				lastBlock = eventLog.BlockNumber
+			}
+		}
+	}
+}
+
+func decodeBidLog(contractAbi abi.ABI, eventLog types.Log) (Bid, error) {
+
+	event, err := contractAbi.Unpack("HighestBidIncreased", eventLog.Data)
+	if err != nil {
+		return Bid{}, err
+	}
+
+	return Bid{
+		Sender: event[0].(common.Address).String(),
+		Amount: event[1].(*big.Int).Int64(),
+	}, nil
+}
+
+// sendBid delivers bid on bids, or abandons the send and reports false if
+// ctx is cancelled first, so a subscriber that has already stopped reading
+// never leaves the sending goroutine blocked forever.
+func sendBid(ctx context.Context, bids chan<- Bid, bid Bid) bool {
+
+	select {
+	case <-ctx.Done():
+		return false
+	case bids <- bid:
+		return true
+	}
+}
+
+// sendErr delivers err on errs, or abandons the send and reports false if
+// ctx is cancelled first, for the same reason as sendBid.
+func sendErr(ctx context.Context, errs chan<- error, err error) bool {
+
+	select {
+	case <-ctx.Done():
+		return false
+	case errs <- err:
+		return true
+	}
+}
+
+func waitBeforeRetry(ctx context.Context, d time.Duration) bool {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,158 @@
+package business
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"math/big"
+	"os"
+	"sync"
+)
+
+type signerRole string
+
+const (
+	SignerBidder   signerRole = "bidder"
+	SignerDeployer signerRole = "deployer"
+)
+
+// nonceKey scopes a local nonce counter to a signer role on a specific
+// chain, since the same key can be used to sign transactions on more than
+// one chain and each chain tracks its own nonce sequence.
+type nonceKey struct {
+	role    signerRole
+	chainID int64
+}
+
+// SignerManager holds the bidder and deployer private keys in memory,
+// loaded once at startup, and builds authorized transactors straight from
+// them instead of round-tripping a scrypt-encrypted keystore file to disk
+// on every request.
+type SignerManager struct {
+	keys map[signerRole]*ecdsa.PrivateKey
+
+	mu        sync.Mutex
+	nextNonce map[nonceKey]uint64
+}
+
+func NewSignerManager() (*SignerManager, error) {
+
+	sm := &SignerManager{
+		keys:      make(map[signerRole]*ecdsa.PrivateKey),
+		nextNonce: make(map[nonceKey]uint64),
+	}
+
+	if err := sm.loadKey(SignerBidder, "BIDDER_ACC_PRIVATE_KEY"); err != nil {
+		return nil, err
+	}
+
+	if err := sm.loadKey(SignerDeployer, "DEPLOYER_ACC_PRIVATE_KEY"); err != nil {
+		return nil, err
+	}
+
+	return sm, nil
+}
+
+func (sm *SignerManager) loadKey(role signerRole, envVar string) error {
+
+	rawKey, found := os.LookupEnv(envVar)
+	if !found {
+		return nil
+	}
+
+	privateKeyBytes, err := hex.DecodeString(rawKey)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := crypto.ToECDSA(privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	sm.keys[role] = privateKey
+
+	return nil
+}
+
+// Transactor builds an authorized transactor for role against the given
+// chain, using a suggested gas tip cap on every call; callers may still
+// override that field before submitting the transaction. The nonce is
+// assigned from a local per-role counter, seeded from the account's pending
+// nonce the first time the role is used and incremented under a lock on
+// every call after that, so two concurrent transactions for the same role
+// never race on the same pending nonce. If the caller's transaction never
+// actually reaches the network (e.g. it reverts during gas estimation),
+// it must call ReleaseNonce so the reserved nonce isn't burned.
+func (sm *SignerManager) Transactor(ctx context.Context, client *ethclient.Client, chainID int64, role signerRole) (*bind.TransactOpts, error) {
+
+	privateKey, found := sm.keys[role]
+	if !found {
+		return nil, fmt.Errorf("no private key configured for the %s signer", role)
+	}
+
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(chainID))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := sm.reserveNonce(ctx, client, role, chainID, crypto.PubkeyToAddress(privateKey.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	auth.Nonce = big.NewInt(int64(nonce))
+
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	auth.GasTipCap = gasTipCap
+
+	return auth, nil
+}
+
+// reserveNonce returns the next nonce to use for role on chainID and
+// advances the local counter past it, so a concurrent call for the same
+// role on the same chain always gets a different nonce instead of
+// re-reading the same pending nonce.
+func (sm *SignerManager) reserveNonce(ctx context.Context, client *ethclient.Client, role signerRole, chainID int64, fromAddr common.Address) (uint64, error) {
+
+	key := nonceKey{role: role, chainID: chainID}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	nonce, seeded := sm.nextNonce[key]
+	if !seeded {
+		pending, err := client.PendingNonceAt(ctx, fromAddr)
+		if err != nil {
+			return 0, err
+		}
+
+		nonce = pending
+	}
+
+	sm.nextNonce[key] = nonce + 1
+
+	return nonce, nil
+}
+
+// ReleaseNonce forgets the locally reserved nonce for role on chainID, so
+// the next Transactor call for that role+chain reseeds it from the chain's
+// pending nonce instead of reusing a nonce whose transaction never actually
+// broadcast. Callers should invoke this when a transaction built from the
+// returned *bind.TransactOpts fails before or during submission.
+func (sm *SignerManager) ReleaseNonce(role signerRole, chainID int64) {
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	delete(sm.nextNonce, nonceKey{role: role, chainID: chainID})
+}
@@ -0,0 +1,618 @@
+package handlers
+
+import (
+	"Web3AuctionApi/business"
+	"Web3AuctionApi/models"
+	"context"
+	"crypto/rand"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+	"log"
+	"math/big"
+	"time"
+)
+
+type AuctionHandler struct {
+	connection business.Connection
+	db         *gorm.DB
+	secretKey  []byte
+}
+
+func NewAuctionHandler(connection business.Connection, db *gorm.DB, secretKey []byte) *AuctionHandler {
+
+	return &AuctionHandler{
+		connection: connection,
+		db:         db,
+		secretKey:  secretKey,
+	}
+}
+
+type deployAuctionRequest struct {
+	ChainID            int64  `validate:"required" json:"chainId"`
+	DurationInSeconds  int64  `validate:"required" json:"durationInSeconds"`
+	BeneficiaryAddress string `validate:"required" json:"beneficiaryAddress"`
+}
+
+// CreateAuction deploys a new auction contract on the requested chain and
+// registers it so it can be looked up, bid on and ended independently of
+// every other auction the service is tracking.
+// @Description Deploy and register a new auction.
+// @Summary Deploy an auction
+// @Tags auction
+// @Accept json
+// @Produce json
+// @Param request body deployAuctionRequest true "Auction to deploy"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Validation failed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions [post]
+func (ah *AuctionHandler) CreateAuction(c *fiber.Ctx) error {
+
+	deployData := deployAuctionRequest{}
+
+	err := c.BodyParser(&deployData)
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	validationErrors := validateRequest(deployData)
+	if validationErrors != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Validation failed", validationErrors)
+	}
+
+	username := authenticatedUsername(c)
+
+	var user models.User
+
+	err = ah.db.First(&user, "username = ?", username).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	contractAddr, txHash, err := ah.connection.Deploy(deployData.ChainID, deployData.DurationInSeconds, deployData.BeneficiaryAddress)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	auction := models.Auction{
+		ContractAddr:   contractAddr,
+		ChainID:        deployData.ChainID,
+		Beneficiary:    deployData.BeneficiaryAddress,
+		DeployerUserID: user.ID,
+	}
+
+	err = ah.db.Create(&auction).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"id":       auction.ID,
+		"contract": contractAddr,
+		"txHash":   txHash,
+	})
+}
+
+type deployBlindAuctionRequest struct {
+	ChainID              int64  `validate:"required" json:"chainId"`
+	BiddingTimeInSeconds int64  `validate:"required" json:"biddingTimeInSeconds"`
+	RevealTimeInSeconds  int64  `validate:"required" json:"revealTimeInSeconds"`
+	BeneficiaryAddress   string `validate:"required" json:"beneficiaryAddress"`
+}
+
+// CreateBlindAuction deploys a new sealed-bid auction contract on the
+// requested chain and registers it alongside the service's other auctions,
+// so it can be looked up and driven through its commit/reveal/withdraw
+// lifecycle like any other registered auction.
+// @Description Deploy and register a new sealed-bid auction.
+// @Summary Deploy a sealed-bid auction
+// @Tags auction
+// @Accept json
+// @Produce json
+// @Param request body deployBlindAuctionRequest true "Sealed-bid auction to deploy"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Validation failed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/blind [post]
+func (ah *AuctionHandler) CreateBlindAuction(c *fiber.Ctx) error {
+
+	deployData := deployBlindAuctionRequest{}
+
+	err := c.BodyParser(&deployData)
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	validationErrors := validateRequest(deployData)
+	if validationErrors != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Validation failed", validationErrors)
+	}
+
+	username := authenticatedUsername(c)
+
+	var user models.User
+
+	err = ah.db.First(&user, "username = ?", username).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	contractAddr, txHash, err := ah.connection.DeployBlindAuction(deployData.ChainID, deployData.BiddingTimeInSeconds, deployData.RevealTimeInSeconds, deployData.BeneficiaryAddress)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	auction := models.Auction{
+		ContractAddr:   contractAddr,
+		ChainID:        deployData.ChainID,
+		Beneficiary:    deployData.BeneficiaryAddress,
+		DeployerUserID: user.ID,
+	}
+
+	err = ah.db.Create(&auction).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"id":       auction.ID,
+		"contract": contractAddr,
+		"txHash":   txHash,
+	})
+}
+
+// ListAuctions returns every auction the registry knows about, across every
+// chain it has been deployed to.
+// @Description List every registered auction.
+// @Summary List auctions
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []models.Auction
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions [get]
+func (ah *AuctionHandler) ListAuctions(c *fiber.Ctx) error {
+
+	var auctions []models.Auction
+
+	err := ah.db.Find(&auctions).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, auctions)
+}
+
+// GetAuction returns the on-chain status of a single registered auction.
+// @Description Get an auction's status.
+// @Summary Get an auction
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} business.AuctionStatus
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id} [get]
+func (ah *AuctionHandler) GetAuction(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	status, err := ah.connection.GetAuctionStatus(common.HexToAddress(auction.ContractAddr), auction.ChainID)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, status)
+}
+
+// GetBlindAuctionStatus returns the current phase and bidding/reveal
+// deadlines of a single registered sealed-bid auction.
+// @Description Get a sealed-bid auction's status.
+// @Summary Get a sealed-bid auction
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} business.AuctionStatus
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/blind-status [get]
+func (ah *AuctionHandler) GetBlindAuctionStatus(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	status, err := ah.connection.BlindAuctionStatus(common.HexToAddress(auction.ContractAddr), auction.ChainID)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, status)
+}
+
+// ListAuctionBids lists every bid submitted to a single registered auction,
+// scoped to its contract address so bids from other auctions never leak in.
+// @Description List an auction's bids.
+// @Summary List bids
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} []business.Bid
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/bids [get]
+func (ah *AuctionHandler) ListAuctionBids(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	bids, err := ah.connection.ListAllBids(common.HexToAddress(auction.ContractAddr), auction.ChainID)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, bids)
+}
+
+type placeBidRequest struct {
+	Amount int64 `validate:"required" json:"amount"`
+}
+
+// PlaceBid submits a plain (non-sealed) bid to a single registered auction.
+// @Description Place a bid.
+// @Summary Place a bid
+// @Tags auction
+// @Accept json
+// @Produce json
+// @Param request body placeBidRequest true "Bid to place"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Validation failed"
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/bids [post]
+func (ah *AuctionHandler) PlaceBid(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	bidData := placeBidRequest{}
+
+	err = c.BodyParser(&bidData)
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	validationErrors := validateRequest(bidData)
+	if validationErrors != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Validation failed", validationErrors)
+	}
+
+	err = ah.connection.Bid(common.HexToAddress(auction.ContractAddr), auction.ChainID, bidData.Amount)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"message": "Bid submitted successfully",
+	})
+}
+
+// EndAuction marks a registered auction as ended by setting its ended_at to
+// the current time. The contract is not called, and ListAuctions still
+// returns the auction.
+// @Description End an auction.
+// @Summary End an auction
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/end [post]
+func (ah *AuctionHandler) EndAuction(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	err = ah.db.Model(&auction).Update("ended_at", time.Now()).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"message": "Auction ended successfully",
+	})
+}
+
+// BidsSocket upgrades the connection to a WebSocket and pushes decoded bid
+// events for a single registered auction to the subscriber as they arrive,
+// so the frontend no longer needs to poll ListAuctionBids/GetAuction.
+// @Description Subscribe to live bid events.
+// @Summary Subscribe to bids
+// @Tags auction
+// @Security ApiKeyAuth
+// @Router /ws/auctions/{id}/bids [get]
+func (ah *AuctionHandler) BidsSocket() fiber.Handler {
+
+	return websocket.New(func(conn *websocket.Conn) {
+
+		var auction models.Auction
+
+		if err := ah.db.First(&auction, "id = ?", conn.Params("id")).Error; err != nil {
+			log.Println(err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		bids, errs := ah.connection.NewAuctionEvents(ctx, common.HexToAddress(auction.ContractAddr), auction.ChainID)
+
+		for {
+			select {
+			case bid, open := <-bids:
+				if !open {
+					return
+				}
+
+				if err := conn.WriteJSON(bid); err != nil {
+					log.Println(err)
+					return
+				}
+			case err, open := <-errs:
+				if !open {
+					return
+				}
+
+				log.Println(err)
+			}
+		}
+	})
+}
+
+type commitBidRequest struct {
+	Amount  int64 `validate:"required" json:"amount"`
+	Fake    bool  `json:"fake"`
+	Deposit int64 `validate:"required" json:"deposit"`
+}
+
+// Commit blinds the bidder's real amount with a server-generated secret,
+// submits the resulting hash to the BlindAuction contract and stores the
+// encrypted secret so the bidder can reveal later without re-submitting it.
+// @Description Commit a blinded bid.
+// @Summary Commit a sealed bid
+// @Tags auction
+// @Accept json
+// @Produce json
+// @Param request body commitBidRequest true "Bid to commit"
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Validation failed"
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/commit [post]
+func (ah *AuctionHandler) Commit(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	commitData := commitBidRequest{}
+
+	err = c.BodyParser(&commitData)
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	}
+
+	validationErrors := validateRequest(commitData)
+	if validationErrors != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Validation failed", validationErrors)
+	}
+
+	username := authenticatedUsername(c)
+
+	var secret [32]byte
+	if _, err = rand.Read(secret[:]); err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	blindedHash := blindBid(commitData.Amount, commitData.Fake, secret)
+
+	encryptedSecret, err := encrypt(ah.secretKey, secret[:])
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	err = ah.connection.CommitBid(common.HexToAddress(auction.ContractAddr), auction.ChainID, blindedHash, commitData.Deposit)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	bidSecret := models.BidSecret{
+		AuctionID: auction.ID,
+		Username:  username,
+		Amount:    commitData.Amount,
+		Fake:      commitData.Fake,
+		Secret:    encryptedSecret,
+	}
+
+	err = ah.db.Create(&bidSecret).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"message": "Bid committed successfully",
+	})
+}
+
+// Reveal discloses every bid the authenticated bidder committed to a single
+// registered auction during its bidding phase, using the secrets the server
+// stored on their behalf.
+// @Description Reveal previously committed bids.
+// @Summary Reveal sealed bids
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/reveal [post]
+func (ah *AuctionHandler) Reveal(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	username := authenticatedUsername(c)
+
+	var bidSecrets []models.BidSecret
+
+	err = ah.db.Where("auction_id = ? AND username = ?", auction.ID, username).Find(&bidSecrets).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	values := make([]int64, len(bidSecrets))
+	fakes := make([]bool, len(bidSecrets))
+	secrets := make([][32]byte, len(bidSecrets))
+
+	for i, bidSecret := range bidSecrets {
+
+		secret, err := decrypt(ah.secretKey, bidSecret.Secret)
+		if err != nil {
+			log.Println(err)
+			return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+		}
+
+		values[i] = bidSecret.Amount
+		fakes[i] = bidSecret.Fake
+		copy(secrets[i][:], secret)
+	}
+
+	err = ah.connection.RevealBids(common.HexToAddress(auction.ContractAddr), auction.ChainID, values, fakes, secrets)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	err = ah.db.Where("auction_id = ? AND username = ?", auction.ID, username).Delete(&models.BidSecret{}).Error
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"message": "Bids revealed successfully",
+	})
+}
+
+// Withdraw pulls back any deposits owed to the authenticated bidder on a
+// single registered auction once they have been outbid.
+// @Description Withdraw an outbid deposit.
+// @Summary Withdraw a deposit
+// @Tags auction
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string "Auction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /auctions/{id}/withdraw [post]
+func (ah *AuctionHandler) Withdraw(c *fiber.Ctx) error {
+
+	auction, err := ah.auctionByID(c)
+	if err != nil {
+		return ah.auctionLookupError(c, err)
+	}
+
+	err = ah.connection.Withdraw(common.HexToAddress(auction.ContractAddr), auction.ChainID)
+	if err != nil {
+		log.Println(err)
+		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+	}
+
+	return successResponse(c, map[string]string{
+		"message": "Withdrawal successful",
+	})
+}
+
+func (ah *AuctionHandler) auctionByID(c *fiber.Ctx) (models.Auction, error) {
+
+	var auction models.Auction
+
+	err := ah.db.First(&auction, "id = ?", c.Params("id")).Error
+
+	return auction, err
+}
+
+func (ah *AuctionHandler) auctionLookupError(c *fiber.Ctx, err error) error {
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorResponse(c, fiber.StatusNotFound, "Auction not found", nil)
+	}
+
+	log.Println(err)
+	return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+}
+
+func authenticatedUsername(c *fiber.Ctx) string {
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+
+	return claims["username"].(string)
+}
+
+// blindBid reproduces the BlindAuction.sol hashing scheme:
+// keccak256(abi.encodePacked(value, fake, secret)).
+func blindBid(amount int64, fake bool, secret [32]byte) [32]byte {
+
+	packed := make([]byte, 0, 65)
+	packed = append(packed, common.LeftPadBytes(big.NewInt(amount).Bytes(), 32)...)
+
+	var fakeByte byte
+	if fake {
+		fakeByte = 1
+	}
+	packed = append(packed, fakeByte)
+	packed = append(packed, secret[:]...)
+
+	return crypto.Keccak256Hash(packed)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"log"
@@ -124,6 +125,7 @@ func (ah *AuthHandler) Login(c *fiber.Ctx) error {
 	// Create the Claims
 	claims := jwt.MapClaims{
 		"username": user.Username,
+		"jti":      uuid.NewString(),
 		"exp":      time.Now().Add(time.Second * time.Duration(ah.tokenDuration)).Unix(),
 	}
 
@@ -158,10 +160,14 @@ func (ah *AuthHandler) Logout(c *fiber.Ctx) error {
 	user := c.Locals("user").(*jwt.Token)
 	claims := user.Claims.(jwt.MapClaims)
 	username := claims["username"].(string)
+	jti, _ := claims["jti"].(string)
+	exp, _ := claims["exp"].(float64)
 
 	invalidToken := models.InvalidToken{
 		Username: username,
 		Token:    user.Raw,
+		Jti:      jti,
+		Exp:      int64(exp),
 	}
 
 	err := ah.db.Create(&invalidToken).Error
@@ -170,6 +176,8 @@ func (ah *AuthHandler) Logout(c *fiber.Ctx) error {
 		return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
 	}
 
+	ah.blacklist.Revoke(jti)
+
 	return successResponse(c, map[string]string{
 		"message": "Token invalidated successfully",
 	})
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"Web3AuctionApi/models"
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+	"log"
+	"sync"
+	"time"
+)
+
+const blacklistSweepInterval = time.Hour
+const blacklistFalsePositiveRate = 0.01
+const minBlacklistFilterCapacity = 1000
+
+// TokenBlacklist keeps a bloom filter of revoked JWT IDs in memory so the
+// auth middleware can reject a revoked token without querying the database
+// on every request. A bloom filter never produces false negatives, so a
+// positive match is confirmed against the database to rule out a false
+// positive before a token is actually treated as revoked.
+type TokenBlacklist struct {
+	db     *gorm.DB
+	mu     sync.RWMutex
+	filter *bloom.BloomFilter
+}
+
+func NewTokenBlacklist(db *gorm.DB) (*TokenBlacklist, error) {
+
+	tb := &TokenBlacklist{
+		db: db,
+	}
+
+	if err := tb.rebuild(); err != nil {
+		return nil, err
+	}
+
+	go tb.sweep()
+
+	return tb, nil
+}
+
+// rebuild reconstructs the bloom filter from scratch, sized to the current
+// InvalidToken row count, and swaps it in atomically. Run after sweep prunes
+// expired rows so the filter's false-positive rate doesn't climb forever as
+// revoked tokens pile up in a filter that never shrinks. Holding mu for the
+// whole rebuild, not just the swap, blocks a concurrent Revoke() until it can
+// be applied to the new filter instead of being lost to the old one.
+func (tb *TokenBlacklist) rebuild() error {
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	var invalidTokens []models.InvalidToken
+
+	err := tb.db.Find(&invalidTokens).Error
+	if err != nil {
+		return err
+	}
+
+	capacity := uint(len(invalidTokens))
+	if capacity < minBlacklistFilterCapacity {
+		capacity = minBlacklistFilterCapacity
+	}
+
+	filter := bloom.NewWithEstimates(capacity, blacklistFalsePositiveRate)
+	for _, invalidToken := range invalidTokens {
+		filter.AddString(invalidToken.Jti)
+	}
+
+	tb.filter = filter
+
+	return nil
+}
+
+// Revoke marks jti as revoked in the in-memory bloom filter. The caller is
+// responsible for persisting the corresponding models.InvalidToken row.
+func (tb *TokenBlacklist) Revoke(jti string) {
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.filter.AddString(jti)
+}
+
+// IsRevoked reports whether jti has been revoked. A bloom miss is trusted
+// outright; a bloom hit is confirmed against the database since the filter
+// can false-positive.
+func (tb *TokenBlacklist) IsRevoked(jti string) (bool, error) {
+
+	tb.mu.RLock()
+	maybeRevoked := tb.filter.TestString(jti)
+	tb.mu.RUnlock()
+
+	if !maybeRevoked {
+		return false, nil
+	}
+
+	var count int64
+
+	err := tb.db.Model(&models.InvalidToken{}).Where("jti = ?", jti).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// sweep periodically evicts InvalidToken rows whose exp claim has passed and
+// rebuilds the bloom filter from what remains, so both the table and the
+// filter's false-positive rate stay bounded.
+func (tb *TokenBlacklist) sweep() {
+
+	ticker := time.NewTicker(blacklistSweepInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+
+		err := tb.db.Where("exp < ?", time.Now().Unix()).Delete(&models.InvalidToken{}).Error
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		if err := tb.rebuild(); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// Middleware rejects requests bearing a revoked token. It must run after
+// the JWT auth middleware so that c.Locals("user") is already populated.
+func (tb *TokenBlacklist) Middleware() fiber.Handler {
+
+	return func(c *fiber.Ctx) error {
+
+		user := c.Locals("user").(*jwt.Token)
+		claims := user.Claims.(jwt.MapClaims)
+		jti, _ := claims["jti"].(string)
+
+		revoked, err := tb.IsRevoked(jti)
+		if err != nil {
+			log.Println(err)
+			return errorResponse(c, fiber.StatusInternalServerError, "Server error", nil)
+		}
+
+		if revoked {
+			return errorResponse(c, fiber.StatusForbidden, "Unauthenticated.", nil)
+		}
+
+		return c.Next()
+	}
+}